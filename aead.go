@@ -0,0 +1,158 @@
+// Copyright (c) 2016 Bob Ziuchkovski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package turing
+
+import (
+	"crypto/cipher"
+	"crypto/subtle"
+	"errors"
+)
+
+// aeadNonceSize is the nonce size required by the AEAD returned from
+// NewAEAD. It's fixed at the largest IV size NewCipher accepts alongside a
+// maximum-length 32-byte key.
+const aeadNonceSize = 16
+
+// errOpen is returned by aead.Open when the authenticator doesn't match the
+// provided ciphertext and additional data.
+var errOpen = errors.New("turing: message authentication failed")
+
+// aead implements cipher.AEAD on top of a Turing keystream, following the
+// construction used by ChaCha20-Poly1305 (RFC 8439): the first 32 bytes of
+// keystream from a fresh (key, nonce) pair are used as a one-time poly1305
+// key, and the remaining keystream is XORed with the plaintext.
+type aead struct {
+	key []byte
+}
+
+// NewAEAD returns a cipher.AEAD that authenticates and encrypts data using
+// Turing as the underlying keystream generator and poly1305 as the MAC, in
+// the style of ChaCha20-Poly1305. The key size restrictions are the same as
+// NewCipher's, and the returned AEAD's NonceSize is 16, which is the largest
+// IV NewCipher accepts alongside a full-length key.
+func NewAEAD(key []byte) (cipher.AEAD, error) {
+	if _, err := NewCipher(key, nil); err != nil {
+		return nil, err
+	}
+	return &aead{key: key}, nil
+}
+
+func (a *aead) NonceSize() int {
+	return aeadNonceSize
+}
+
+func (a *aead) Overhead() int {
+	return poly1305TagSize
+}
+
+func (a *aead) Seal(dst, nonce, plaintext, additionalData []byte) []byte {
+	if len(nonce) != aeadNonceSize {
+		panic("turing: incorrect nonce length given to AEAD.Seal")
+	}
+
+	c, err := NewCipher(a.key, nonce)
+	if err != nil {
+		panic("turing: " + err.Error())
+	}
+
+	var macKey [32]byte
+	c.XORKeyStream(macKey[:], macKey[:])
+
+	ret, ciphertext := sliceForAppend(dst, len(plaintext)+poly1305TagSize)
+	c.XORKeyStream(ciphertext, plaintext)
+
+	var tag [poly1305TagSize]byte
+	poly1305Sum(&tag, macBuffer(additionalData, ciphertext[:len(plaintext)]), &macKey)
+	copy(ciphertext[len(plaintext):], tag[:])
+
+	return ret
+}
+
+func (a *aead) Open(dst, nonce, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(nonce) != aeadNonceSize {
+		panic("turing: incorrect nonce length given to AEAD.Open")
+	}
+	if len(ciphertext) < poly1305TagSize {
+		return nil, errOpen
+	}
+
+	tag := ciphertext[len(ciphertext)-poly1305TagSize:]
+	ciphertext = ciphertext[:len(ciphertext)-poly1305TagSize]
+
+	c, err := NewCipher(a.key, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	var macKey [32]byte
+	c.XORKeyStream(macKey[:], macKey[:])
+
+	var wantTag [poly1305TagSize]byte
+	poly1305Sum(&wantTag, macBuffer(additionalData, ciphertext), &macKey)
+	if subtle.ConstantTimeCompare(wantTag[:], tag) != 1 {
+		return nil, errOpen
+	}
+
+	ret, plaintext := sliceForAppend(dst, len(ciphertext))
+	c.XORKeyStream(plaintext, ciphertext)
+	return ret, nil
+}
+
+// macBuffer builds the authenticated message for the poly1305 MAC,
+// following the ChaCha20-Poly1305 construction: additional data and
+// ciphertext are each zero-padded out to a 16-byte boundary, followed by
+// their little-endian uint64 lengths. Padding the inputs out to full blocks
+// lets poly1305Sum operate on whole blocks only.
+func macBuffer(additionalData, ciphertext []byte) []byte {
+	adPad := (16 - len(additionalData)%16) % 16
+	ctPad := (16 - len(ciphertext)%16) % 16
+
+	buf := make([]byte, 0, len(additionalData)+adPad+len(ciphertext)+ctPad+16)
+	buf = append(buf, additionalData...)
+	buf = append(buf, make([]byte, adPad)...)
+	buf = append(buf, ciphertext...)
+	buf = append(buf, make([]byte, ctPad)...)
+	buf = append(buf, le64(uint64(len(additionalData)))...)
+	buf = append(buf, le64(uint64(len(ciphertext)))...)
+	return buf
+}
+
+func le64(v uint64) []byte {
+	return []byte{
+		byte(v), byte(v >> 8), byte(v >> 16), byte(v >> 24),
+		byte(v >> 32), byte(v >> 40), byte(v >> 48), byte(v >> 56),
+	}
+}
+
+// sliceForAppend extends the in slice by n bytes and returns the extended
+// slice and the slice of n bytes at its end, reusing in's capacity when
+// possible. It's the same helper used by the standard library's AEAD
+// implementations (e.g. crypto/cipher/gcm.go).
+func sliceForAppend(in []byte, n int) (head, tail []byte) {
+	if total := len(in) + n; cap(in) >= total {
+		head = in[:total]
+	} else {
+		head = make([]byte, total)
+		copy(head, in)
+	}
+	tail = head[len(in):]
+	return
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2016 Bob Ziuchkovski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package turing
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"testing"
+)
+
+func newTestAEAD(t *testing.T) (a cipher.AEAD, key, nonce []byte) {
+	t.Helper()
+	key = bytes.Repeat([]byte{0x2a}, 32)
+	a, err := NewAEAD(key)
+	if err != nil {
+		t.Fatalf("NewAEAD: %v", err)
+	}
+	nonce = bytes.Repeat([]byte{0x11}, a.NonceSize())
+	return a, key, nonce
+}
+
+func TestAEADSealOpenRoundTrip(t *testing.T) {
+	a, _, nonce := newTestAEAD(t)
+
+	cases := []struct {
+		name           string
+		plaintext      []byte
+		additionalData []byte
+	}{
+		{"empty plaintext and AD", nil, nil},
+		{"plaintext only", []byte("hello, turing"), nil},
+		{"AD only", nil, []byte("header")},
+		{"plaintext and AD", []byte("the quick brown fox jumps over the lazy dog"), []byte("metadata")},
+		{"block-aligned plaintext", bytes.Repeat([]byte{0x42}, 64), bytes.Repeat([]byte{0x07}, 16)},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			sealed := a.Seal(nil, nonce, c.plaintext, c.additionalData)
+			if len(sealed) != len(c.plaintext)+a.Overhead() {
+				t.Fatalf("len(sealed) = %d, want %d", len(sealed), len(c.plaintext)+a.Overhead())
+			}
+
+			opened, err := a.Open(nil, nonce, sealed, c.additionalData)
+			if err != nil {
+				t.Fatalf("Open: %v", err)
+			}
+			if !bytes.Equal(opened, c.plaintext) {
+				t.Fatalf("Open = %q, want %q", opened, c.plaintext)
+			}
+		})
+	}
+}
+
+func TestAEADOpenRejectsTampering(t *testing.T) {
+	a, _, nonce := newTestAEAD(t)
+	plaintext := []byte("the quick brown fox jumps over the lazy dog")
+	additionalData := []byte("metadata")
+	sealed := a.Seal(nil, nonce, plaintext, additionalData)
+
+	t.Run("tampered ciphertext", func(t *testing.T) {
+		tampered := append([]byte(nil), sealed...)
+		tampered[0] ^= 0x01
+		if _, err := a.Open(nil, nonce, tampered, additionalData); err == nil {
+			t.Fatal("Open succeeded on tampered ciphertext")
+		}
+	})
+
+	t.Run("tampered tag", func(t *testing.T) {
+		tampered := append([]byte(nil), sealed...)
+		tampered[len(tampered)-1] ^= 0x01
+		if _, err := a.Open(nil, nonce, tampered, additionalData); err == nil {
+			t.Fatal("Open succeeded on tampered tag")
+		}
+	})
+
+	t.Run("tampered additional data", func(t *testing.T) {
+		tamperedAD := append([]byte(nil), additionalData...)
+		tamperedAD[0] ^= 0x01
+		if _, err := a.Open(nil, nonce, sealed, tamperedAD); err == nil {
+			t.Fatal("Open succeeded with tampered additional data")
+		}
+	})
+
+	t.Run("truncated ciphertext", func(t *testing.T) {
+		if _, err := a.Open(nil, nonce, sealed[:len(sealed)-1], additionalData); err == nil {
+			t.Fatal("Open succeeded on truncated ciphertext")
+		}
+	})
+}
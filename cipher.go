@@ -24,9 +24,16 @@
 package turing
 
 import (
+	"crypto/cipher"
+	"encoding/binary"
 	"fmt"
 )
 
+// Cipher implements the crypto/cipher.Stream interface, so it can be used
+// anywhere a standard library stream cipher is expected, e.g. with
+// cipher.StreamReader/StreamWriter.
+var _ cipher.Stream = (*Cipher)(nil)
+
 const reglen = 17
 const minkey = 8
 const maxkey = 32
@@ -57,22 +64,18 @@ type Cipher struct {
 // exceed 48 bytes.  These restrictions are part of the algorithm specs.
 func NewCipher(key []byte, iv []byte) (cipher *Cipher, err error) {
 	keylen := len(key)
-	ivlen := len(iv)
 
 	if keylen%4 != 0 {
 		return nil, KeySizeError("key size must be a multiple of 4")
 	}
-	if ivlen%4 != 0 {
-		return nil, KeySizeError("iv size must be a multiple of 4")
-	}
 	if keylen < minkey {
 		return nil, KeySizeError(fmt.Sprintf("key size must be >= %d", minkey))
 	}
 	if keylen > maxkey {
 		return nil, KeySizeError(fmt.Sprintf("key size must be <= %d", maxkey))
 	}
-	if keylen+ivlen > maxiv {
-		return nil, KeySizeError(fmt.Sprintf("combined key and iv sizes must be <= %d", maxiv))
+	if err := validateIV(keylen, iv); err != nil {
+		return nil, err
 	}
 
 	cipher = &Cipher{}
@@ -82,6 +85,39 @@ func NewCipher(key []byte, iv []byte) (cipher *Cipher, err error) {
 	return
 }
 
+// validateIV applies the IV-related restrictions shared by NewCipher and
+// SetIV: the IV size must be a multiple of 4 bytes, and the combined size
+// of the key and IV must not exceed maxiv.
+func validateIV(keylen int, iv []byte) error {
+	ivlen := len(iv)
+	if ivlen%4 != 0 {
+		return KeySizeError("iv size must be a multiple of 4")
+	}
+	if keylen+ivlen > maxiv {
+		return KeySizeError(fmt.Sprintf("combined key and iv sizes must be <= %d", maxiv))
+	}
+	return nil
+}
+
+// SetIV rekeys the Cipher with a new IV, reusing the already-computed keyed
+// sboxes instead of re-running the expensive per-key initialization that
+// NewCipher performs. This makes it practical for protocols that rotate the
+// IV on every message, the way AES-GCM or ChaCha20-Poly1305 do per TLS
+// record, to do so cheaply.
+//
+// The same (key, IV) pair must never be reused to encrypt more than one
+// message: doing so allows an attacker to recover the XOR of the two
+// plaintexts, exactly as with any other stream cipher.
+func (cipher *Cipher) SetIV(iv []byte) error {
+	if err := validateIV(len(cipher.key)*4, iv); err != nil {
+		return err
+	}
+
+	cipher.initIV(iv)
+	cipher.nextRound()
+	return nil
+}
+
 // Reset makes a best effort attempt to remove the key data from memory.
 // However, go's garbage-collecting semantics make it impossible to provide
 // an absolute guarantee that the key data is completely unreachable.
@@ -115,6 +151,50 @@ func (cipher *Cipher) XORKeyStream(dst, src []byte) {
 	}
 }
 
+// Keystream fills dst with raw keystream bytes, equivalent to calling
+// XORKeyStream with a src of all zeroes. It's useful for callers that need
+// the keystream itself rather than an XORed result, e.g. to derive
+// sub-keys.
+func (cipher *Cipher) Keystream(dst []byte) {
+	for i := range dst {
+		if cipher.bufpos == len(cipher.buffer) {
+			cipher.nextRound()
+		}
+		dst[i] = cipher.buffer[cipher.bufpos]
+		cipher.bufpos++
+	}
+}
+
+// Skip advances the cipher state by n bytes without emitting output,
+// equivalent to discarding n bytes of keystream. It consumes any buffered
+// keystream first, then runs whole rounds, so it does no more work than
+// generating and discarding n bytes of keystream would.
+func (cipher *Cipher) Skip(n uint64) {
+	if remain := uint64(len(cipher.buffer) - cipher.bufpos); remain > 0 {
+		if n <= remain {
+			cipher.bufpos += int(n)
+			return
+		}
+		n -= remain
+		cipher.bufpos = len(cipher.buffer)
+	}
+
+	for n >= uint64(len(cipher.buffer)) {
+		cipher.nextRound()
+		// nextRound leaves bufpos at 0, i.e. the round it just generated
+		// is fully unread. Since that whole round is being skipped, mark
+		// it exhausted instead, or a full round would be left re-readable
+		// and Skip would under-advance by len(buffer) bytes.
+		cipher.bufpos = len(cipher.buffer)
+		n -= uint64(len(cipher.buffer))
+	}
+
+	if n > 0 {
+		cipher.nextRound()
+		cipher.bufpos = int(n)
+	}
+}
+
 func (cipher *Cipher) nextRound() {
 	cipher.clockRegister()
 	a, b, c, d, e := cipher.reg[16], cipher.reg[13], cipher.reg[6], cipher.reg[1], cipher.reg[0]
@@ -131,16 +211,21 @@ func (cipher *Cipher) nextRound() {
 	cipher.clockRegister()
 
 	a, b, c, d, e = a+cipher.reg[14], b+cipher.reg[12], c+cipher.reg[8], d+cipher.reg[1], e+cipher.reg[0]
-	copy(cipher.buffer[0:4], splitWord(a))
-	copy(cipher.buffer[4:8], splitWord(b))
-	copy(cipher.buffer[8:12], splitWord(c))
-	copy(cipher.buffer[12:16], splitWord(d))
-	copy(cipher.buffer[16:20], splitWord(e))
+	binary.BigEndian.PutUint32(cipher.buffer[0:4], a)
+	binary.BigEndian.PutUint32(cipher.buffer[4:8], b)
+	binary.BigEndian.PutUint32(cipher.buffer[8:12], c)
+	binary.BigEndian.PutUint32(cipher.buffer[12:16], d)
+	binary.BigEndian.PutUint32(cipher.buffer[16:20], e)
 	cipher.bufpos = 0
 
 	cipher.clockRegister()
 }
 
+// clockRegister has no assembly fast path: unlike keyedS's keybox, which is
+// a field of Cipher with a type declared in this file, mtab's declaration
+// isn't present in this source tree (tables.go is absent from this
+// snapshot), so its layout can't be assumed from assembly without risking
+// reading it incorrectly.
 func (cipher *Cipher) clockRegister() {
 	word := cipher.reg[15] ^ cipher.reg[4] ^ (cipher.reg[0] << 8) ^ mtab[cipher.reg[0]>>24]
 	for i := 0; i < reglen-1; i++ {
@@ -149,13 +234,11 @@ func (cipher *Cipher) clockRegister() {
 	cipher.reg[reglen-1] = word
 }
 
-// We use the pre-calculated keyed sbox approach outlined in "Turing: a Fast Stream Cipher"
+// We use the pre-calculated keyed sbox approach outlined in "Turing: a Fast
+// Stream Cipher". keyedSImpl has an assembly fast path on amd64; see
+// turing_amd64.s and turing_generic.go.
 func (cipher *Cipher) keyedS(word uint32, rotate uint) uint32 {
-	var s uint32
-	for i, octet := range splitWord(rotl(word, rotate)) {
-		s ^= cipher.keybox[i][octet]
-	}
-	return s
+	return keyedSImpl(&cipher.keybox, word, uint32(rotate))
 }
 
 func (cipher *Cipher) initKey(key []byte) {
@@ -0,0 +1,43 @@
+// Copyright (c) 2016 Bob Ziuchkovski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package turing
+
+import "testing"
+
+// benchmarkXORKeyStream mirrors the shape of the standard library's
+// crypto/cipher stream benchmarks: a fixed key/IV, steady-state XORing of
+// a buffer of the given size against itself, repeated b.N times.
+func benchmarkXORKeyStream(b *testing.B, size int) {
+	c, err := NewCipher(make([]byte, 32), make([]byte, 16))
+	if err != nil {
+		b.Fatal(err)
+	}
+	buf := make([]byte, size)
+	b.SetBytes(int64(size))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c.XORKeyStream(buf, buf)
+	}
+}
+
+func BenchmarkXORKeyStream1K(b *testing.B) { benchmarkXORKeyStream(b, 1<<10) }
+func BenchmarkXORKeyStream8K(b *testing.B) { benchmarkXORKeyStream(b, 8<<10) }
+func BenchmarkXORKeyStream1M(b *testing.B) { benchmarkXORKeyStream(b, 1<<20) }
@@ -0,0 +1,208 @@
+// Copyright (c) 2016 Bob Ziuchkovski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bufio"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	turing "github.com/bobziuchkovski/turing"
+)
+
+// On-disk framing:
+//
+//	magic[4] | keylen[1] | saltlen[1] | salt[saltlen]
+//	( chunklen[4] | sealed[chunklen+16] )*
+//
+// salt is random and combined with a per-chunk counter to build the
+// 16-byte nonce turing.NewAEAD requires, so no (key, nonce) pair is ever
+// reused even though every chunk is sealed with the same key. keylen and
+// saltlen are recorded for tooling/inspection; keylen isn't otherwise used
+// since the key itself is supplied out of band. Each chunk is sealed
+// independently, so corruption in one chunk is detected, and localized to,
+// that chunk alone.
+var magic = [4]byte{'T', 'U', 'R', '1'}
+
+const saltSize = 8
+const chunkSize = 64 * 1024
+
+// counterSize is the width, in bytes, of the per-chunk counter appended to
+// the header salt to build each chunk's AEAD nonce.
+const counterSize = 8
+
+func runEncrypt(args []string) error {
+	fs := flag.NewFlagSet("encrypt", flag.ExitOnError)
+	resolveKey := keySource(fs)
+	fs.Parse(args)
+
+	key, err := resolveKey()
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	return encryptStream(w, os.Stdin, key)
+}
+
+// encryptStream writes a turing-framed, chunk-authenticated stream to w for
+// the plaintext read from r, using key. It's split out from runEncrypt so
+// it can be exercised directly with in-memory buffers in tests, without
+// going through os.Stdin/os.Stdout.
+func encryptStream(w io.Writer, r io.Reader, key []byte) error {
+	var salt [saltSize]byte
+	if _, err := io.ReadFull(rand.Reader, salt[:]); err != nil {
+		return err
+	}
+
+	a, err := turing.NewAEAD(key)
+	if err != nil {
+		return err
+	}
+
+	if _, err := w.Write(magic[:]); err != nil {
+		return err
+	}
+	if _, err := w.Write([]byte{byte(len(key)), saltSize}); err != nil {
+		return err
+	}
+	if _, err := w.Write(salt[:]); err != nil {
+		return err
+	}
+
+	buf := make([]byte, chunkSize)
+	var counter uint64
+	for {
+		n, err := io.ReadFull(r, buf)
+		if n > 0 {
+			if serr := sealChunk(w, a, salt, counter, buf[:n]); serr != nil {
+				return serr
+			}
+			counter++
+		}
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func sealChunk(w io.Writer, a cipher.AEAD, salt [saltSize]byte, counter uint64, plaintext []byte) error {
+	nonce := make([]byte, a.NonceSize())
+	copy(nonce, salt[:])
+	binary.BigEndian.PutUint64(nonce[saltSize:], counter)
+
+	var lenBuf [4]byte
+	binary.BigEndian.PutUint32(lenBuf[:], uint32(len(plaintext)))
+	if _, err := w.Write(lenBuf[:]); err != nil {
+		return err
+	}
+
+	sealed := a.Seal(nil, nonce, plaintext, nil)
+	_, err := w.Write(sealed)
+	return err
+}
+
+func runDecrypt(args []string) error {
+	fs := flag.NewFlagSet("decrypt", flag.ExitOnError)
+	resolveKey := keySource(fs)
+	fs.Parse(args)
+
+	key, err := resolveKey()
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(os.Stdout)
+	defer w.Flush()
+	return decryptStream(w, os.Stdin, key)
+}
+
+// decryptStream reads a turing-framed stream from r, verifies and decrypts
+// each chunk using key, and writes the recovered plaintext to w. It's split
+// out from runDecrypt so it can be exercised directly with in-memory
+// buffers in tests, without going through os.Stdin/os.Stdout.
+func decryptStream(w io.Writer, r io.Reader, key []byte) error {
+	br := bufio.NewReader(r)
+
+	var hdr [6]byte
+	if _, err := io.ReadFull(br, hdr[:]); err != nil {
+		return fmt.Errorf("reading header: %w", err)
+	}
+	if hdr[0] != magic[0] || hdr[1] != magic[1] || hdr[2] != magic[2] || hdr[3] != magic[3] {
+		return fmt.Errorf("not a turing-framed stream")
+	}
+	saltLen := hdr[5]
+
+	a, err := turing.NewAEAD(key)
+	if err != nil {
+		return err
+	}
+	// The per-chunk nonce is salt||counter, so the salt must leave room for
+	// the counterSize-byte counter within the AEAD's nonce; otherwise a
+	// corrupt or crafted saltlen byte would make the PutUint64 below write
+	// past the end of the nonce.
+	if int(saltLen)+counterSize > a.NonceSize() {
+		return fmt.Errorf("salt length %d leaves no room for the chunk counter in a %d-byte nonce", saltLen, a.NonceSize())
+	}
+
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(br, salt); err != nil {
+		return fmt.Errorf("reading salt: %w", err)
+	}
+
+	var counter uint64
+	for {
+		var lenBuf [4]byte
+		if _, err := io.ReadFull(br, lenBuf[:]); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("reading chunk %d length: %w", counter, err)
+		}
+		chunkLen := binary.BigEndian.Uint32(lenBuf[:])
+
+		sealed := make([]byte, int(chunkLen)+a.Overhead())
+		if _, err := io.ReadFull(br, sealed); err != nil {
+			return fmt.Errorf("reading chunk %d: %w", counter, err)
+		}
+
+		nonce := make([]byte, a.NonceSize())
+		copy(nonce, salt)
+		binary.BigEndian.PutUint64(nonce[len(salt):], counter)
+
+		plaintext, err := a.Open(nil, nonce, sealed, nil)
+		if err != nil {
+			return fmt.Errorf("chunk %d: %w", counter, err)
+		}
+		if _, err := w.Write(plaintext); err != nil {
+			return err
+		}
+		counter++
+	}
+}
@@ -0,0 +1,112 @@
+// Copyright (c) 2016 Bob Ziuchkovski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"testing"
+)
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatalf("rand.Read: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		size int
+	}{
+		{"empty", 0},
+		{"single chunk", 1024},
+		{"exact chunk boundary", chunkSize},
+		{"multiple chunks", chunkSize*2 + 1024},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plaintext := make([]byte, tt.size)
+			if _, err := rand.Read(plaintext); err != nil {
+				t.Fatalf("rand.Read: %v", err)
+			}
+
+			var framed bytes.Buffer
+			if err := encryptStream(&framed, bytes.NewReader(plaintext), key); err != nil {
+				t.Fatalf("encryptStream: %v", err)
+			}
+
+			var decrypted bytes.Buffer
+			if err := decryptStream(&decrypted, bytes.NewReader(framed.Bytes()), key); err != nil {
+				t.Fatalf("decryptStream: %v", err)
+			}
+
+			if !bytes.Equal(decrypted.Bytes(), plaintext) {
+				t.Fatalf("decrypted %d bytes, want %d bytes matching the original plaintext", decrypted.Len(), len(plaintext))
+			}
+		})
+	}
+}
+
+func TestEncryptDecryptRoundTripWithPassphrase(t *testing.T) {
+	key, err := deriveKey("correct horse battery staple", 32)
+	if err != nil {
+		t.Fatalf("deriveKey: %v", err)
+	}
+
+	plaintext := []byte("exercise the -pass path end to end")
+	var framed bytes.Buffer
+	if err := encryptStream(&framed, bytes.NewReader(plaintext), key); err != nil {
+		t.Fatalf("encryptStream: %v", err)
+	}
+
+	var decrypted bytes.Buffer
+	if err := decryptStream(&decrypted, bytes.NewReader(framed.Bytes()), key); err != nil {
+		t.Fatalf("decryptStream: %v", err)
+	}
+	if !bytes.Equal(decrypted.Bytes(), plaintext) {
+		t.Fatalf("decrypted %q, want %q", decrypted.Bytes(), plaintext)
+	}
+}
+
+// TestDecryptRejectsOversizedSalt guards against the saltlen header byte
+// being corrupted (or crafted) to a value that would leave the per-chunk
+// nonce with no room for the counter: decryptStream must return an error
+// rather than panic.
+func TestDecryptRejectsOversizedSalt(t *testing.T) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		t.Fatalf("rand.Read: %v", err)
+	}
+
+	var framed bytes.Buffer
+	if err := encryptStream(&framed, bytes.NewReader(nil), key); err != nil {
+		t.Fatalf("encryptStream: %v", err)
+	}
+
+	corrupt := framed.Bytes()
+	corrupt[5] = 16 // saltlen: leaves no room for the 8-byte counter in a 16-byte nonce
+
+	var decrypted bytes.Buffer
+	if err := decryptStream(&decrypted, bytes.NewReader(corrupt), key); err == nil {
+		t.Fatal("decryptStream succeeded with a corrupt saltlen, want an error")
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2016 Bob Ziuchkovski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/binary"
+)
+
+// kdfSalt is fixed rather than random because the CLI has no place to
+// store a per-invocation salt alongside the derived key: the same
+// passphrase must always derive the same key so that `turing decrypt`
+// run later, possibly on another machine, can reproduce it. Callers who
+// want per-file salts should prefer -key with a randomly generated key
+// file instead of -pass.
+var kdfSalt = []byte("turing-cli-passphrase-kdf-v1")
+
+const kdfIterations = 600000
+
+// deriveKey derives an n-byte key from passphrase using PBKDF2-HMAC-SHA256.
+// The module has no dependency on scrypt or argon2 implementations, both
+// of which live outside the standard library, so this CLI uses the
+// stdlib-only construction instead; callers who need a memory-hard KDF
+// should derive the key themselves and pass it via -key.
+func deriveKey(passphrase string, n int) ([]byte, error) {
+	mac := hmac.New(sha256.New, []byte(passphrase))
+	hLen := mac.Size()
+
+	numBlocks := (n + hLen - 1) / hLen
+	dk := make([]byte, 0, numBlocks*hLen)
+
+	for block := uint32(1); block <= uint32(numBlocks); block++ {
+		mac.Reset()
+		mac.Write(kdfSalt)
+		var blockIndex [4]byte
+		binary.BigEndian.PutUint32(blockIndex[:], block)
+		mac.Write(blockIndex[:])
+
+		u := mac.Sum(nil)
+		t := make([]byte, hLen)
+		copy(t, u)
+		for i := 1; i < kdfIterations; i++ {
+			mac.Reset()
+			mac.Write(u)
+			u = mac.Sum(nil)
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		dk = append(dk, t...)
+	}
+
+	return dk[:n], nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2016 Bob Ziuchkovski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDeriveKeyIsDeterministic(t *testing.T) {
+	a, err := deriveKey("correct horse battery staple", 32)
+	if err != nil {
+		t.Fatalf("deriveKey: %v", err)
+	}
+	b, err := deriveKey("correct horse battery staple", 32)
+	if err != nil {
+		t.Fatalf("deriveKey: %v", err)
+	}
+	if !bytes.Equal(a, b) {
+		t.Fatal("deriveKey returned different keys for the same passphrase and length")
+	}
+
+	other, err := deriveKey("a different passphrase", 32)
+	if err != nil {
+		t.Fatalf("deriveKey: %v", err)
+	}
+	if bytes.Equal(a, other) {
+		t.Fatal("deriveKey returned the same key for two different passphrases")
+	}
+}
+
+func TestDeriveKeyLength(t *testing.T) {
+	for _, n := range []int{8, 16, 32} {
+		key, err := deriveKey("passphrase", n)
+		if err != nil {
+			t.Fatalf("deriveKey(n=%d): %v", n, err)
+		}
+		if len(key) != n {
+			t.Fatalf("deriveKey(n=%d) returned %d bytes", n, len(key))
+		}
+	}
+}
@@ -0,0 +1,183 @@
+// Copyright (c) 2016 Bob Ziuchkovski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package turing
+
+// poly1305TagSize is the size, in bytes, of a poly1305 authenticator.
+const poly1305TagSize = 16
+
+// poly1305Sum computes the poly1305 one-time authenticator of msg using the
+// given 32-byte key and writes the 16-byte result to out. The caller must
+// ensure len(msg) is a multiple of 16, which the AEAD construction in
+// aead.go guarantees by padding additional data and ciphertext to the block
+// boundary before authenticating, matching the construction used by
+// ChaCha20-Poly1305 (RFC 8439).
+//
+// This is the classic "poly1305-donna" 26-bit-limb portable algorithm,
+// operating on 32-bit words so it doesn't require a 64x64->128 bit multiply.
+func poly1305Sum(out *[poly1305TagSize]byte, msg []byte, key *[32]byte) {
+	var (
+		t0 = uint32(key[0]) | uint32(key[1])<<8 | uint32(key[2])<<16 | uint32(key[3])<<24
+		t1 = uint32(key[4]) | uint32(key[5])<<8 | uint32(key[6])<<16 | uint32(key[7])<<24
+		t2 = uint32(key[8]) | uint32(key[9])<<8 | uint32(key[10])<<16 | uint32(key[11])<<24
+		t3 = uint32(key[12]) | uint32(key[13])<<8 | uint32(key[14])<<16 | uint32(key[15])<<24
+	)
+
+	r0 := t0 & 0x3ffffff
+	t0 >>= 26
+	t0 |= t1 << 6
+	r1 := t0 & 0x3ffff03
+	t1 >>= 20
+	t1 |= t2 << 12
+	r2 := t1 & 0x3ffc0ff
+	t2 >>= 14
+	t2 |= t3 << 18
+	r3 := t2 & 0x3f03fff
+	t3 >>= 8
+	r4 := t3 & 0x00fffff
+
+	s1 := r1 * 5
+	s2 := r2 * 5
+	s3 := r3 * 5
+	s4 := r4 * 5
+
+	pad0 := uint32(key[16]) | uint32(key[17])<<8 | uint32(key[18])<<16 | uint32(key[19])<<24
+	pad1 := uint32(key[20]) | uint32(key[21])<<8 | uint32(key[22])<<16 | uint32(key[23])<<24
+	pad2 := uint32(key[24]) | uint32(key[25])<<8 | uint32(key[26])<<16 | uint32(key[27])<<24
+	pad3 := uint32(key[28]) | uint32(key[29])<<8 | uint32(key[30])<<16 | uint32(key[31])<<24
+
+	var h0, h1, h2, h3, h4 uint32
+
+	for len(msg) >= 16 {
+		m0 := uint32(msg[0]) | uint32(msg[1])<<8 | uint32(msg[2])<<16 | uint32(msg[3])<<24
+		m1 := uint32(msg[4]) | uint32(msg[5])<<8 | uint32(msg[6])<<16 | uint32(msg[7])<<24
+		m2 := uint32(msg[8]) | uint32(msg[9])<<8 | uint32(msg[10])<<16 | uint32(msg[11])<<24
+		m3 := uint32(msg[12]) | uint32(msg[13])<<8 | uint32(msg[14])<<16 | uint32(msg[15])<<24
+
+		h0 += m0 & 0x3ffffff
+		h1 += ((m0 >> 26) | (m1 << 6)) & 0x3ffffff
+		h2 += ((m1 >> 20) | (m2 << 12)) & 0x3ffffff
+		h3 += ((m2 >> 14) | (m3 << 18)) & 0x3ffffff
+		h4 += (m3 >> 8) | (1 << 24)
+
+		d0 := uint64(h0)*uint64(r0) + uint64(h1)*uint64(s4) + uint64(h2)*uint64(s3) + uint64(h3)*uint64(s2) + uint64(h4)*uint64(s1)
+		d1 := uint64(h0)*uint64(r1) + uint64(h1)*uint64(r0) + uint64(h2)*uint64(s4) + uint64(h3)*uint64(s3) + uint64(h4)*uint64(s2)
+		d2 := uint64(h0)*uint64(r2) + uint64(h1)*uint64(r1) + uint64(h2)*uint64(r0) + uint64(h3)*uint64(s4) + uint64(h4)*uint64(s3)
+		d3 := uint64(h0)*uint64(r3) + uint64(h1)*uint64(r2) + uint64(h2)*uint64(r1) + uint64(h3)*uint64(r0) + uint64(h4)*uint64(s4)
+		d4 := uint64(h0)*uint64(r4) + uint64(h1)*uint64(r3) + uint64(h2)*uint64(r2) + uint64(h3)*uint64(r1) + uint64(h4)*uint64(r0)
+
+		var c uint32
+		c = uint32(d0 >> 26)
+		h0 = uint32(d0) & 0x3ffffff
+		d1 += uint64(c)
+		c = uint32(d1 >> 26)
+		h1 = uint32(d1) & 0x3ffffff
+		d2 += uint64(c)
+		c = uint32(d2 >> 26)
+		h2 = uint32(d2) & 0x3ffffff
+		d3 += uint64(c)
+		c = uint32(d3 >> 26)
+		h3 = uint32(d3) & 0x3ffffff
+		d4 += uint64(c)
+		c = uint32(d4 >> 26)
+		h4 = uint32(d4) & 0x3ffffff
+		h0 += c * 5
+		c = h0 >> 26
+		h0 &= 0x3ffffff
+		h1 += c
+
+		msg = msg[16:]
+	}
+
+	var c uint32
+	c = h1 >> 26
+	h1 &= 0x3ffffff
+	h2 += c
+	c = h2 >> 26
+	h2 &= 0x3ffffff
+	h3 += c
+	c = h3 >> 26
+	h3 &= 0x3ffffff
+	h4 += c
+	c = h4 >> 26
+	h4 &= 0x3ffffff
+	h0 += c * 5
+	c = h0 >> 26
+	h0 &= 0x3ffffff
+	h1 += c
+
+	g0 := h0 + 5
+	c = g0 >> 26
+	g0 &= 0x3ffffff
+	g1 := h1 + c
+	c = g1 >> 26
+	g1 &= 0x3ffffff
+	g2 := h2 + c
+	c = g2 >> 26
+	g2 &= 0x3ffffff
+	g3 := h3 + c
+	c = g3 >> 26
+	g3 &= 0x3ffffff
+	g4 := h4 + c - (1 << 26)
+
+	mask := (g4 >> 31) - 1
+	g0 &= mask
+	g1 &= mask
+	g2 &= mask
+	g3 &= mask
+	g4 &= mask
+	mask = ^mask
+	h0 = (h0 & mask) | g0
+	h1 = (h1 & mask) | g1
+	h2 = (h2 & mask) | g2
+	h3 = (h3 & mask) | g3
+	h4 = (h4 & mask) | g4
+
+	h0 = (h0 | (h1 << 26)) & 0xffffffff
+	h1 = ((h1 >> 6) | (h2 << 20)) & 0xffffffff
+	h2 = ((h2 >> 12) | (h3 << 14)) & 0xffffffff
+	h3 = ((h3 >> 18) | (h4 << 8)) & 0xffffffff
+
+	f := uint64(h0) + uint64(pad0)
+	h0 = uint32(f)
+	f = uint64(h1) + uint64(pad1) + (f >> 32)
+	h1 = uint32(f)
+	f = uint64(h2) + uint64(pad2) + (f >> 32)
+	h2 = uint32(f)
+	f = uint64(h3) + uint64(pad3) + (f >> 32)
+	h3 = uint32(f)
+
+	out[0] = byte(h0)
+	out[1] = byte(h0 >> 8)
+	out[2] = byte(h0 >> 16)
+	out[3] = byte(h0 >> 24)
+	out[4] = byte(h1)
+	out[5] = byte(h1 >> 8)
+	out[6] = byte(h1 >> 16)
+	out[7] = byte(h1 >> 24)
+	out[8] = byte(h2)
+	out[9] = byte(h2 >> 8)
+	out[10] = byte(h2 >> 16)
+	out[11] = byte(h2 >> 24)
+	out[12] = byte(h3)
+	out[13] = byte(h3 >> 8)
+	out[14] = byte(h3 >> 16)
+	out[15] = byte(h3 >> 24)
+}
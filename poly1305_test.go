@@ -0,0 +1,57 @@
+// Copyright (c) 2016 Bob Ziuchkovski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package turing
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+)
+
+// TestPoly1305SumRFC8439Vector checks poly1305Sum using the key and message
+// of the test vector from RFC 8439 section 2.5.2. The message is zero-padded
+// out to the 16-byte boundary poly1305Sum requires, matching how aead.go
+// always calls it, so the expected tag is that of the 48-byte padded message
+// rather than the RFC's tag for the unpadded 34-byte one.
+func TestPoly1305SumRFC8439Vector(t *testing.T) {
+	var key [32]byte
+	copy(key[:], mustDecodeHex(t, "85d6be7857556d337f4452fe42d506a80103808afb0db2fd4abff6af4149f51b"))
+
+	msg := []byte("Cryptographic Forum Research Group")
+	msg = append(msg, make([]byte, (16-len(msg)%16)%16)...)
+
+	want := mustDecodeHex(t, "c88886f51af32a75f0fdf57c4a7defdd")
+
+	var got [poly1305TagSize]byte
+	poly1305Sum(&got, msg, &key)
+	if !bytes.Equal(got[:], want) {
+		t.Errorf("poly1305Sum(RFC 8439 vector) = %x, want %x", got, want)
+	}
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("hex.DecodeString(%q): %v", s, err)
+	}
+	return b
+}
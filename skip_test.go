@@ -0,0 +1,110 @@
+// Copyright (c) 2016 Bob Ziuchkovski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package turing
+
+import (
+	"bytes"
+	"testing"
+)
+
+// TestSkipMatchesKeystream checks that Skip(n) followed by Keystream(m)
+// always produces the same bytes as generating n+m bytes of keystream up
+// front and slicing off the first n. n is varied across and around round
+// boundaries (the buffer is 20 bytes per round), since Skip special-cases
+// whole rounds.
+func TestSkipMatchesKeystream(t *testing.T) {
+	key := []byte("0123456789abcdef")
+	iv := []byte("abcdefgh")
+	const tail = 32
+
+	ns := []uint64{
+		0, 1, 19, 20, 21,
+		39, 40, 41,
+		59, 60, 61,
+		79, 80, 81,
+		99, 100, 101,
+		150,
+	}
+
+	for _, n := range ns {
+		n := n
+		t.Run("", func(t *testing.T) {
+			want, err := NewCipher(key, iv)
+			if err != nil {
+				t.Fatalf("NewCipher: %v", err)
+			}
+			full := make([]byte, n+tail)
+			want.Keystream(full)
+
+			got, err := NewCipher(key, iv)
+			if err != nil {
+				t.Fatalf("NewCipher: %v", err)
+			}
+			got.Skip(n)
+			gotTail := make([]byte, tail)
+			got.Keystream(gotTail)
+
+			if !bytes.Equal(gotTail, full[n:]) {
+				t.Fatalf("Skip(%d) then Keystream(%d) = %x, want %x", n, tail, gotTail, full[n:])
+			}
+		})
+	}
+}
+
+// TestSkipInTwoCalls checks that Skip is additive: skipping n then m bytes
+// must advance the same amount as skipping n+m in one call, including when
+// n or m lands mid-round.
+func TestSkipInTwoCalls(t *testing.T) {
+	key := []byte("0123456789abcdef")
+	iv := []byte("abcdefgh")
+	const tail = 24
+
+	pairs := [][2]uint64{
+		{0, 20}, {20, 0}, {10, 10}, {19, 1}, {1, 19},
+		{20, 20}, {15, 25}, {40, 5},
+	}
+
+	for _, p := range pairs {
+		n, m := p[0], p[1]
+		t.Run("", func(t *testing.T) {
+			want, err := NewCipher(key, iv)
+			if err != nil {
+				t.Fatalf("NewCipher: %v", err)
+			}
+			want.Skip(n + m)
+			wantTail := make([]byte, tail)
+			want.Keystream(wantTail)
+
+			got, err := NewCipher(key, iv)
+			if err != nil {
+				t.Fatalf("NewCipher: %v", err)
+			}
+			got.Skip(n)
+			got.Skip(m)
+			gotTail := make([]byte, tail)
+			got.Keystream(gotTail)
+
+			if !bytes.Equal(gotTail, wantTail) {
+				t.Fatalf("Skip(%d) then Skip(%d) = %x, want Skip(%d) = %x", n, m, gotTail, n+m, wantTail)
+			}
+		})
+	}
+}
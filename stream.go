@@ -0,0 +1,42 @@
+// Copyright (c) 2016 Bob Ziuchkovski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package turing
+
+import (
+	"crypto/cipher"
+	"io"
+)
+
+// NewReader returns a reader that transforms the bytes read from r by
+// XORing them with the keystream produced by c. It is a thin wrapper
+// around cipher.StreamReader.
+func NewReader(r io.Reader, c *Cipher) io.Reader {
+	return &cipher.StreamReader{S: c, R: r}
+}
+
+// NewWriter returns a writer that transforms the bytes written to it by
+// XORing them with the keystream produced by c before writing the result
+// to w. It is a thin wrapper around cipher.StreamWriter, which does not
+// buffer: each Write is passed straight through to w. Close closes w if w
+// implements io.Closer and otherwise does nothing.
+func NewWriter(w io.Writer, c *Cipher) io.WriteCloser {
+	return &cipher.StreamWriter{S: c, W: w}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2016 Bob Ziuchkovski
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+//go:build !amd64 || purego
+
+package turing
+
+// keyedSImpl is the portable fallback for architectures (or builds tagged
+// purego) without an assembly fast path. It XORs together one lookup per
+// byte of word, after rotating word left by rotate bits, from each of the
+// four keyed sboxes in keybox.
+func keyedSImpl(keybox *[4][256]uint32, word uint32, rotate uint32) uint32 {
+	word = rotl(word, uint(rotate))
+	var s uint32
+	for i := uint(0); i < 4; i++ {
+		s ^= keybox[i][getOctet(word, i)]
+	}
+	return s
+}
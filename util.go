@@ -24,15 +24,6 @@ func getOctet(word uint32, n uint) byte {
 	return byte((word >> (24 - n*8)) & 0xff)
 }
 
-func splitWord(word uint32) []byte {
-	var octets [4]byte
-	octets[0] = byte((word >> 24) & 0xff)
-	octets[1] = byte((word >> 16) & 0xff)
-	octets[2] = byte((word >> 8) & 0xff)
-	octets[3] = byte(word & 0xff)
-	return octets[:]
-}
-
 func joinWord(octets [4]byte) uint32 {
 	return (uint32(octets[0]) << 24) | (uint32(octets[1]) << 16) | (uint32(octets[2]) << 8) | uint32(octets[3])
 }